@@ -0,0 +1,218 @@
+package password // import "github.com/nathanaelle/password"
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/subtle"
+	"fmt"
+)
+
+type (
+	apr1driver struct{}
+
+	apr1pwd struct {
+		salt   []byte
+		hashed [22]byte
+	}
+)
+
+const (
+	apr1Prefix = "$apr1$"
+)
+
+// APR1 is the exported driver for the Apache APR1-MD5 crypt format used by
+// .htpasswd files generated with `htpasswd -m`.
+var APR1 = register(apr1driver{})
+
+func (d apr1driver) String() string {
+	return "{APR1-MD5}"
+}
+
+func (d apr1driver) Prefix() []byte {
+	return []byte(apr1Prefix)
+}
+
+func (d apr1driver) Options() map[string]interface{} {
+	return map[string]interface{}{}
+}
+
+func (d apr1driver) SetOptions(o map[string]interface{}) Definition {
+	return d
+}
+
+func (d apr1driver) Default() Crypter {
+	return &apr1pwd{}
+}
+
+func (d apr1driver) Crypt(pwd, salt []byte, options map[string]interface{}) []byte {
+	b, _ := d.SetOptions(options).Default().Salt(salt).Crypt(pwd).MarshalText()
+	return b
+}
+
+func (d apr1driver) CrypterFound(hash []byte) (Crypter, bool) {
+	if len(hash) < len(apr1Prefix) || string(hash[0:len(apr1Prefix)]) != apr1Prefix {
+		return nil, false
+	}
+
+	p := new(apr1pwd)
+	if err := p.Set(hash); err != nil {
+		return nil, false
+	}
+
+	return p, true
+}
+
+func (p *apr1pwd) Salt(salt []byte) Crypter {
+	if salt == nil || len(salt) == 0 {
+		return &apr1pwd{getrandh64(8), p.hashed}
+	}
+	var s [8]byte
+
+	l := copy(s[:], salt)
+
+	return &apr1pwd{s[0:l], p.hashed}
+}
+
+func (p *apr1pwd) Hashed(hashed []byte) Crypter {
+	var s [22]byte
+
+	if hashed == nil || len(hashed) == 0 {
+		return &apr1pwd{p.salt, s}
+	}
+
+	copy(s[:], hashed)
+
+	return &apr1pwd{p.salt, s}
+}
+
+func (p *apr1pwd) Options() map[string]interface{} {
+	return p.Definition().Options()
+}
+
+func (p *apr1pwd) Definition() Definition {
+	return apr1driver{}
+}
+
+func (p *apr1pwd) Crypt(pwd []byte) Crypter {
+	np := new(apr1pwd)
+	*np = *p
+
+	copy(np.hashed[:], h64Encode(p.crypt(pwd)))
+
+	return np
+}
+
+func (p *apr1pwd) String() string {
+	return fmt.Sprintf(apr1Prefix+"%s$%s", p.salt, p.hashed)
+}
+
+func (p *apr1pwd) Verify(pwd []byte) bool {
+	if pwd == nil || len(pwd) == 0 {
+		return false
+	}
+
+	he := h64Encode(p.crypt(pwd))
+	return (subtle.ConstantTimeCompare(he, p.hashed[:]) == 1)
+}
+
+func (p *apr1pwd) Set(hash []byte) error {
+	if p == nil {
+		return ERR_NOPE
+	}
+
+	if len(hash) < len(apr1Prefix) || string(hash[0:len(apr1Prefix)]) != apr1Prefix {
+		return ERR_NOPE
+	}
+
+	list := bytes.SplitN(hash[len(apr1Prefix):], []byte("$"), 2)
+
+	switch len(list) {
+	case 1:
+		*p = *(p.Salt(list[0]).(*apr1pwd))
+		return nil
+
+	case 2:
+		*p = *(p.Salt(list[0]).Hashed(list[1]).(*apr1pwd))
+		return nil
+	}
+
+	return ERR_NOPE
+}
+
+func (p *apr1pwd) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *apr1pwd) UnmarshalText(hash []byte) error {
+	return p.Set(hash)
+}
+
+// crypt runs the classic Poul-Henning Kamp MD5 algorithm with the $apr1$
+// magic substituted in, as used by Apache's AP_MD5 and htpasswd -m.
+func (p *apr1pwd) crypt(pwd []byte) []byte {
+	magic := []byte(apr1Prefix)
+
+	ctx1 := md5.New()
+	ctx1.Write(pwd)
+	ctx1.Write(p.salt)
+	ctx1.Write(pwd)
+	final := ctx1.Sum(nil)
+
+	ctx := md5.New()
+	ctx.Write(pwd)
+	ctx.Write(magic)
+	ctx.Write(p.salt)
+
+	for pl := len(pwd); pl > 0; pl -= 16 {
+		if pl > 16 {
+			ctx.Write(final)
+		} else {
+			ctx.Write(final[0:pl])
+		}
+	}
+
+	for i := len(pwd); i != 0; i >>= 1 {
+		if i&1 != 0 {
+			ctx.Write([]byte{0})
+		} else {
+			ctx.Write(pwd[0:1])
+		}
+	}
+
+	final = ctx.Sum(nil)
+
+	for i := 0; i < 1000; i++ {
+		ctx1 := md5.New()
+
+		if i&1 != 0 {
+			ctx1.Write(pwd)
+		} else {
+			ctx1.Write(final)
+		}
+
+		if i%3 != 0 {
+			ctx1.Write(p.salt)
+		}
+
+		if i%7 != 0 {
+			ctx1.Write(pwd)
+		}
+
+		if i&1 != 0 {
+			ctx1.Write(final)
+		} else {
+			ctx1.Write(pwd)
+		}
+
+		final = ctx1.Sum(nil)
+	}
+
+	return []byte{
+		final[12], final[6], final[0],
+		final[13], final[7], final[1],
+		final[14], final[8], final[2],
+		final[15], final[9], final[3],
+		final[5], final[10], final[4],
+		final[11],
+	}
+}
@@ -0,0 +1,29 @@
+package password
+
+import "testing"
+
+// TestAPR1Crypt checks the $apr1$ output against a vector cross-checked
+// with `openssl passwd -apr1 -salt r31.CWaZ myPassword`.
+func TestAPR1Crypt(t *testing.T) {
+	const want = "$apr1$r31.CWaZ$QSo1b0haHiK059wgC8mMM1"
+
+	got := string(APR1.Crypt([]byte("myPassword"), []byte("r31.CWaZ"), nil))
+	if got != want {
+		t.Fatalf("Crypt() = %q, want %q", got, want)
+	}
+}
+
+func TestAPR1VerifyRoundTrip(t *testing.T) {
+	hash := APR1.Crypt([]byte("myPassword"), nil, nil)
+
+	c, ok := APR1.CrypterFound(hash)
+	if !ok {
+		t.Fatalf("CrypterFound(%q) = _, false", hash)
+	}
+	if !c.Verify([]byte("myPassword")) {
+		t.Fatal("Verify of the matching password failed")
+	}
+	if c.Verify([]byte("wrongPassword")) {
+		t.Fatal("Verify of a non-matching password succeeded")
+	}
+}
@@ -0,0 +1,153 @@
+package password // import "github.com/nathanaelle/password"
+
+import (
+	"golang.org/x/crypto/bcrypt"
+)
+
+type (
+	bcryptdriver struct {
+		cost int
+	}
+
+	bcryptpwd struct {
+		cost   int
+		hashed []byte
+		err    error
+	}
+)
+
+const (
+	bcryptDefCost = bcrypt.DefaultCost
+
+	bcryptPrefix = "$2y$"
+)
+
+// BCRYPT is the exported driver for the bcrypt ($2a$/$2b$/$2y$) format.
+var BCRYPT = register(bcryptdriver{bcryptDefCost})
+
+func (d bcryptdriver) String() string {
+	return "{BCRYPT}"
+}
+
+// Prefix covers "$2$", "$2a$", "$2b$" and "$2y$" alike; CrypterFound does
+// the real parse once the trie has narrowed it down to this driver.
+func (d bcryptdriver) Prefix() []byte {
+	return []byte("$2")
+}
+
+func (d bcryptdriver) Options() map[string]interface{} {
+	return map[string]interface{}{
+		"cost": d.cost,
+	}
+}
+
+func (d bcryptdriver) SetOptions(o map[string]interface{}) Definition {
+	iv, ok := o["cost"]
+	if !ok {
+		return d
+	}
+	v, ok := iv.(int)
+	if !ok {
+		return d
+	}
+
+	return bcryptdriver{bounded(bcrypt.MinCost, v, bcrypt.MaxCost)}
+}
+
+func (d bcryptdriver) Default() Crypter {
+	return &bcryptpwd{
+		cost: d.cost,
+	}
+}
+
+func (d bcryptdriver) Crypt(pwd, salt []byte, options map[string]interface{}) []byte {
+	b, _ := d.SetOptions(options).Default().Crypt(pwd).MarshalText()
+	return b
+}
+
+func (d bcryptdriver) CrypterFound(hash []byte) (Crypter, bool) {
+	if len(hash) < len(bcryptPrefix) || hash[0] != '$' || hash[1] != '2' {
+		return nil, false
+	}
+
+	p := new(bcryptpwd)
+	if err := p.Set(hash); err != nil {
+		return nil, false
+	}
+
+	return p, true
+}
+
+// Salt is a no-op for bcrypt: GenerateFromPassword draws its own salt from
+// crypto/rand and embeds it in the resulting hash, so there is nothing for
+// a caller-supplied salt to do.
+func (p *bcryptpwd) Salt(salt []byte) Crypter {
+	return p
+}
+
+func (p *bcryptpwd) Hashed(hashed []byte) Crypter {
+	np := new(bcryptpwd)
+	*np = *p
+	np.hashed = append([]byte(nil), hashed...)
+
+	return np
+}
+
+func (p *bcryptpwd) Options() map[string]interface{} {
+	return p.Definition().Options()
+}
+
+func (p *bcryptpwd) Definition() Definition {
+	return bcryptdriver{p.cost}
+}
+
+func (p *bcryptpwd) Crypt(pwd []byte) Crypter {
+	hashed, err := bcrypt.GenerateFromPassword(pwd, p.cost)
+	if err != nil {
+		// Surface the failure (e.g. ErrPasswordTooLong for pwd > 72
+		// bytes, or a bad cost) through MarshalText instead of silently
+		// handing back a Crypter whose hashed is empty.
+		return &bcryptpwd{cost: p.cost, err: err}
+	}
+
+	return &bcryptpwd{cost: p.cost, hashed: hashed}
+}
+
+func (p *bcryptpwd) String() string {
+	return string(p.hashed)
+}
+
+func (p *bcryptpwd) Verify(pwd []byte) bool {
+	if pwd == nil || len(pwd) == 0 {
+		return false
+	}
+
+	return bcrypt.CompareHashAndPassword(p.hashed, pwd) == nil
+}
+
+func (p *bcryptpwd) Set(hash []byte) error {
+	if p == nil {
+		return ERR_NOPE
+	}
+
+	cost, err := bcrypt.Cost(hash)
+	if err != nil {
+		return ERR_NOPE
+	}
+
+	*p = bcryptpwd{cost: cost, hashed: append([]byte(nil), hash...)}
+
+	return nil
+}
+
+func (p *bcryptpwd) MarshalText() ([]byte, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	return append([]byte(nil), p.hashed...), nil
+}
+
+func (p *bcryptpwd) UnmarshalText(hash []byte) error {
+	return p.Set(hash)
+}
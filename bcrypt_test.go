@@ -0,0 +1,30 @@
+package password
+
+import "testing"
+
+func TestBcryptVerifyRoundTrip(t *testing.T) {
+	hash := BCRYPT.Crypt([]byte("hunter2"), nil, map[string]interface{}{"cost": 4})
+
+	c, ok := BCRYPT.CrypterFound(hash)
+	if !ok {
+		t.Fatalf("CrypterFound(%q) = _, false", hash)
+	}
+	if !c.Verify([]byte("hunter2")) {
+		t.Fatal("Verify of the matching password failed")
+	}
+	if c.Verify([]byte("wrong")) {
+		t.Fatal("Verify of a non-matching password succeeded")
+	}
+}
+
+func TestBcryptTooLongPasswordFails(t *testing.T) {
+	long := make([]byte, 73)
+	for i := range long {
+		long[i] = 'a'
+	}
+
+	p := BCRYPT.SetOptions(map[string]interface{}{"cost": 4}).Default().Crypt(long)
+	if _, err := p.MarshalText(); err == nil {
+		t.Fatal("MarshalText() = nil error, want bcrypt's 72-byte limit to surface")
+	}
+}
@@ -0,0 +1,89 @@
+// Command passwd-migrate converts a foreign IRC services account dump
+// (Atheme flat-file, Anope db_old, or ircd-hybrid) into a normalized JSON
+// record stream on stdout, one record per line.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/nathanaelle/password"
+	"github.com/nathanaelle/password/migrate"
+)
+
+// rehashDrivers maps the -rehash-to prefix flag to a registered driver.
+var rehashDrivers = map[string]password.Definition{
+	"$5$":    password.SHA256,
+	"$apr1$": password.APR1,
+	"$2y$":   password.BCRYPT,
+}
+
+func main() {
+	format := flag.String("format", "atheme", "source format: atheme, anope, or hybrid")
+	rehashTo := flag.String("rehash-to", "", "driver prefix to rehash into ($5$, $apr1$, or $2y$); requires -pass")
+	pass := flag.String("pass", "", "plaintext password used to verify and rehash with -rehash-to")
+	flag.Parse()
+
+	var src migrate.Source
+	switch *format {
+	case "atheme":
+		src = migrate.Atheme{}
+	case "anope":
+		src = migrate.Anope{}
+	case "hybrid":
+		src = migrate.Hybrid{}
+	default:
+		log.Fatalf("passwd-migrate: unknown -format %q", *format)
+	}
+
+	in := os.Stdin
+	if flag.NArg() > 0 {
+		f, err := os.Open(flag.Arg(0))
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	records, err := src.Parse(in)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var driver password.Definition
+	if *rehashTo != "" {
+		if *pass == "" {
+			log.Fatal("passwd-migrate: -rehash-to requires -pass")
+		}
+
+		d, ok := rehashDrivers[*rehashTo]
+		if !ok {
+			log.Fatalf("passwd-migrate: unknown -rehash-to driver %q, must be one of $5$, $apr1$, $2y$", *rehashTo)
+		}
+		driver = d
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+
+	enc := json.NewEncoder(w)
+	for _, rec := range records {
+		if driver != nil {
+			upgraded, err := migrate.Rehash(rec, []byte(*pass), driver)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "passwd-migrate: %v\n", err)
+			} else {
+				rec = upgraded
+			}
+		}
+
+		if err := enc.Encode(rec); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
@@ -1,10 +1,10 @@
 package password // import "github.com/nathanaelle/password"
 
 import (
+	"bytes"
 	"crypto/rand"
 	"hash"
 	"strconv"
-	"strings"
 )
 
 const h64Alphabet = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
@@ -67,25 +67,27 @@ func h64Encode(src []byte) []byte {
 
 // options parses a "key=value,key2=value2" options fragment from an
 // encoded hash (e.g. "rounds=5000"), or returns nil if s has no "=" and
-// is therefore a bare salt instead.
-func options(s string) map[string]interface{} {
-	if !strings.Contains(s, "=") {
+// is therefore a bare salt instead. It takes []byte directly so the
+// common case (a bare salt, no "=" anywhere) never pays a string copy on
+// the hash-verification hot path.
+func options(s []byte) map[string]interface{} {
+	if !bytes.Contains(s, []byte("=")) {
 		return nil
 	}
 
 	out := make(map[string]interface{})
-	for _, kv := range strings.Split(s, ",") {
-		k, v, ok := strings.Cut(kv, "=")
+	for _, kv := range bytes.Split(s, []byte(",")) {
+		k, v, ok := bytes.Cut(kv, []byte("="))
 		if !ok {
 			continue
 		}
 
-		if n, err := strconv.Atoi(v); err == nil {
-			out[k] = n
+		if n, err := strconv.Atoi(string(v)); err == nil {
+			out[string(k)] = n
 			continue
 		}
 
-		out[k] = v
+		out[string(k)] = string(v)
 	}
 
 	return out
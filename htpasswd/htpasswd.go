@@ -0,0 +1,215 @@
+// Package htpasswd reads and writes Apache/nginx-style .htpasswd files,
+// dispatching each line's hash to the password package's driver registry.
+package htpasswd // import "github.com/nathanaelle/password/htpasswd"
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/nathanaelle/password"
+)
+
+type (
+	// File is an in-memory, mutex-guarded view of a .htpasswd file.
+	File struct {
+		mu      sync.RWMutex
+		path    string
+		entries map[string]password.Crypter
+		watcher *fsnotify.Watcher
+	}
+)
+
+// Open reads path into memory, parsing each "user:hash" line with whichever
+// driver password.CrypterFound identifies from the hash's prefix. A missing
+// file is not an error: it yields an empty File ready for Set and Save.
+func Open(path string) (*File, error) {
+	f := &File{
+		path:    path,
+		entries: make(map[string]password.Crypter),
+	}
+
+	raw, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := f.parse(raw); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (f *File) parse(raw []byte) error {
+	entries := make(map[string]password.Crypter)
+
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+
+		idx := bytes.IndexByte(line, ':')
+		if idx < 0 {
+			continue
+		}
+
+		user := string(line[0:idx])
+
+		c, ok := password.CrypterFound(line[idx+1:])
+		if !ok {
+			// A format this module doesn't ship a driver for (e.g. $6$ or
+			// plain DES crypt) shouldn't make every other entry in the
+			// file unusable: keep the raw hash around as an unverifiable
+			// entry so Verify fails safe and Save round-trips it as-is.
+			c = password.NewUnrecognizedCrypter(line[idx+1:])
+		}
+
+		entries[user] = c
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	f.entries = entries
+	return nil
+}
+
+// Verify reports whether pass matches the stored hash for user.
+func (f *File) Verify(user, pass []byte) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	c, ok := f.entries[string(user)]
+	if !ok {
+		return false
+	}
+
+	return c.Verify(pass)
+}
+
+// Set hashes pass with driver and stores it for user, replacing any
+// existing entry. Callers must call Save to persist the change to disk.
+func (f *File) Set(user, pass []byte, driver password.Definition) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	c := driver.Default().Salt(nil).Crypt(pass)
+
+	// driver.Crypt can fail silently (e.g. bcrypt's 72-byte password
+	// limit): MarshalText is where that failure surfaces, so check it
+	// before the broken entry ever reaches f.entries.
+	if _, err := c.MarshalText(); err != nil {
+		return fmt.Errorf("htpasswd: %s: %w", driver, err)
+	}
+
+	f.entries[string(user)] = c
+
+	return nil
+}
+
+// Delete removes user's entry. Callers must call Save to persist the
+// change to disk.
+func (f *File) Delete(user []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.entries, string(user))
+
+	return nil
+}
+
+// Save rewrites the htpasswd file atomically via a tempfile-plus-rename so
+// that concurrent readers never observe a partially written file.
+func (f *File) Save() error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	var buf bytes.Buffer
+	for user, c := range f.entries {
+		hash, err := c.MarshalText()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(&buf, "%s:%s\n", user, hash)
+	}
+
+	// ioutil.TempFile always creates at 0600, which would silently
+	// downgrade a .htpasswd commonly served at 0644; carry the original
+	// mode forward (or fall back to 0644 for a file that doesn't exist
+	// yet) before the rename replaces it.
+	mode := os.FileMode(0644)
+	if fi, err := os.Stat(f.path); err == nil {
+		mode = fi.Mode().Perm()
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(f.path), ".htpasswd-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Chmod(tmp.Name(), mode); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), f.path)
+}
+
+// Watch starts an fsnotify watch on the file's directory and reloads its
+// entries whenever the file itself changes on disk, e.g. because another
+// process edited it out from under us. Call the returned func to stop.
+func (f *File) Watch() (func() error, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := w.Add(filepath.Dir(f.path)); err != nil {
+		w.Close()
+		return nil, err
+	}
+
+	f.watcher = w
+
+	go func() {
+		for event := range w.Events {
+			if filepath.Clean(event.Name) != filepath.Clean(f.path) {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+
+			raw, err := ioutil.ReadFile(f.path)
+			if err != nil {
+				continue
+			}
+
+			f.mu.Lock()
+			f.parse(raw)
+			f.mu.Unlock()
+		}
+	}()
+
+	return w.Close, nil
+}
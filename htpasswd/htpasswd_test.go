@@ -0,0 +1,84 @@
+package htpasswd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/nathanaelle/password"
+)
+
+func TestFileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".htpasswd")
+
+	f, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	if err := f.Set([]byte("alice"), []byte("hunter2"), password.APR1); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := f.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	f2, err := Open(path)
+	if err != nil {
+		t.Fatalf("re-Open() error = %v", err)
+	}
+
+	if !f2.Verify([]byte("alice"), []byte("hunter2")) {
+		t.Fatal("Verify of the matching password failed after round trip")
+	}
+	if f2.Verify([]byte("alice"), []byte("wrong")) {
+		t.Fatal("Verify of a non-matching password succeeded")
+	}
+
+	if err := f2.Delete([]byte("alice")); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := f2.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	f3, err := Open(path)
+	if err != nil {
+		t.Fatalf("re-Open() after Delete error = %v", err)
+	}
+	if f3.Verify([]byte("alice"), []byte("hunter2")) {
+		t.Fatal("Verify succeeded for a deleted entry")
+	}
+}
+
+func TestFileOpenMissingIsNotError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "does-not-exist")
+
+	f, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if f.Verify([]byte("anyone"), []byte("anything")) {
+		t.Fatal("Verify succeeded against an empty File")
+	}
+}
+
+func TestFileParseUnrecognizedHash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".htpasswd")
+
+	f, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	f.entries["bob"] = password.NewUnrecognizedCrypter([]byte("$6$unsupported$hash"))
+	if err := f.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	f2, err := Open(path)
+	if err != nil {
+		t.Fatalf("re-Open() error = %v", err)
+	}
+	if f2.Verify([]byte("bob"), []byte("anything")) {
+		t.Fatal("Verify succeeded for an unrecognized hash")
+	}
+}
@@ -0,0 +1,171 @@
+package password // import "github.com/nathanaelle/password"
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+)
+
+type (
+	lshadriver struct {
+		salted bool
+	}
+
+	lshapwd struct {
+		salted bool
+		salt   []byte
+		hashed []byte
+	}
+)
+
+const (
+	shaPrefix  = "{SHA}"
+	sshaPrefix = "{SSHA}"
+)
+
+// SHA1 is the exported driver for the unsalted LDAP {SHA} format.
+var SHA1 = register(lshadriver{false})
+
+// SSHA1 is the exported driver for the salted LDAP {SSHA} format.
+var SSHA1 = register(lshadriver{true})
+
+func (d lshadriver) prefix() string {
+	if d.salted {
+		return sshaPrefix
+	}
+	return shaPrefix
+}
+
+func (d lshadriver) String() string {
+	if d.salted {
+		return "{SSHA}"
+	}
+	return "{SHA}"
+}
+
+// Prefix returns "" because {SHA}/{SSHA} aren't $id$-prefixed; register
+// files these drivers under the legacy fallback list instead of the trie.
+func (d lshadriver) Prefix() []byte {
+	return nil
+}
+
+func (d lshadriver) Options() map[string]interface{} {
+	return map[string]interface{}{}
+}
+
+func (d lshadriver) SetOptions(o map[string]interface{}) Definition {
+	return d
+}
+
+func (d lshadriver) Default() Crypter {
+	return &lshapwd{salted: d.salted}
+}
+
+func (d lshadriver) Crypt(pwd, salt []byte, options map[string]interface{}) []byte {
+	b, _ := d.SetOptions(options).Default().Salt(salt).Crypt(pwd).MarshalText()
+	return b
+}
+
+func (d lshadriver) CrypterFound(hash []byte) (Crypter, bool) {
+	prefix := []byte(d.prefix())
+	if len(hash) < len(prefix) || !bytes.Equal(hash[0:len(prefix)], prefix) {
+		return nil, false
+	}
+
+	p := &lshapwd{salted: d.salted}
+	if err := p.Set(hash); err != nil {
+		return nil, false
+	}
+
+	return p, true
+}
+
+func (p *lshapwd) Salt(salt []byte) Crypter {
+	if !p.salted {
+		return &lshapwd{false, nil, p.hashed}
+	}
+	if salt == nil || len(salt) == 0 {
+		return &lshapwd{true, getrandh64(8), p.hashed}
+	}
+
+	return &lshapwd{true, append([]byte(nil), salt...), p.hashed}
+}
+
+func (p *lshapwd) Hashed(hashed []byte) Crypter {
+	return &lshapwd{p.salted, p.salt, append([]byte(nil), hashed...)}
+}
+
+func (p *lshapwd) Options() map[string]interface{} {
+	return p.Definition().Options()
+}
+
+func (p *lshapwd) Definition() Definition {
+	return lshadriver{p.salted}
+}
+
+func (p *lshapwd) Crypt(pwd []byte) Crypter {
+	np := new(lshapwd)
+	*np = *p
+	np.hashed = p.digest(pwd)
+
+	return np
+}
+
+func (p *lshapwd) digest(pwd []byte) []byte {
+	h := sha1.New()
+	h.Write(pwd)
+	if p.salted {
+		h.Write(p.salt)
+	}
+
+	return h.Sum(nil)
+}
+
+func (p *lshapwd) String() string {
+	buf := append(append([]byte(nil), p.hashed...), p.salt...)
+
+	return p.Definition().(lshadriver).prefix() + base64.StdEncoding.EncodeToString(buf)
+}
+
+func (p *lshapwd) Verify(pwd []byte) bool {
+	if pwd == nil || len(pwd) == 0 {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(p.digest(pwd), p.hashed) == 1
+}
+
+func (p *lshapwd) Set(hash []byte) error {
+	if p == nil {
+		return ERR_NOPE
+	}
+
+	prefix := []byte(shaPrefix)
+	if p.salted {
+		prefix = []byte(sshaPrefix)
+	}
+
+	if len(hash) < len(prefix) || !bytes.Equal(hash[0:len(prefix)], prefix) {
+		return ERR_NOPE
+	}
+
+	raw := make([]byte, base64.StdEncoding.DecodedLen(len(hash)-len(prefix)))
+	n, err := base64.StdEncoding.Decode(raw, hash[len(prefix):])
+	if err != nil || n < sha1.Size {
+		return ERR_NOPE
+	}
+
+	p.hashed = raw[0:sha1.Size]
+	p.salt = raw[sha1.Size:n]
+
+	return nil
+}
+
+func (p *lshapwd) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *lshapwd) UnmarshalText(hash []byte) error {
+	return p.Set(hash)
+}
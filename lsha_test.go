@@ -0,0 +1,29 @@
+package password
+
+import "testing"
+
+// TestSHA1Crypt checks the unsalted {SHA} output against a vector
+// cross-checked with hashlib.sha1(b"hunter2").digest(), base64-encoded.
+func TestSHA1Crypt(t *testing.T) {
+	const want = "{SHA}87u9ZqY9S/F0eUBXjsPQEDUw4h0="
+
+	got := string(SHA1.Crypt([]byte("hunter2"), nil, nil))
+	if got != want {
+		t.Fatalf("Crypt() = %q, want %q", got, want)
+	}
+}
+
+func TestSSHA1VerifyRoundTrip(t *testing.T) {
+	hash := SSHA1.Crypt([]byte("hunter2"), nil, nil)
+
+	c, ok := SSHA1.CrypterFound(hash)
+	if !ok {
+		t.Fatalf("CrypterFound(%q) = _, false", hash)
+	}
+	if !c.Verify([]byte("hunter2")) {
+		t.Fatal("Verify of the matching password failed")
+	}
+	if c.Verify([]byte("wrong")) {
+		t.Fatal("Verify of a non-matching password succeeded")
+	}
+}
@@ -0,0 +1,67 @@
+package migrate
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Anope parses the pre-2.0 Anope "db_old" NickServ database format, an
+// indented, per-nick block of "KEY value" lines starting at a NICKNAME
+// record.
+type Anope struct{}
+
+// Parse implements Source.
+func (Anope) Parse(r io.Reader) ([]Record, error) {
+	var out []Record
+	var cur *Record
+
+	flush := func() {
+		if cur != nil {
+			_, cur.Recognized = identify([]byte(cur.Hash))
+			out = append(out, *cur)
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		switch fields[0] {
+		case "NICKNAME":
+			flush()
+			cur = &Record{Name: fields[1]}
+
+		case "PASS":
+			if cur != nil {
+				cur.Hash = fields[1]
+			}
+
+		case "EMAIL":
+			if cur != nil {
+				cur.Email = fields[1]
+			}
+
+		case "TIME":
+			if cur != nil {
+				cur.RegisteredAt, _ = strconv.ParseInt(fields[1], 10, 64)
+			}
+
+		case "LINK":
+			if cur != nil {
+				cur.AdditionalNicks = append(cur.AdditionalNicks, fields[1])
+			}
+		}
+	}
+	flush()
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
@@ -0,0 +1,68 @@
+package migrate
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Atheme parses Atheme IRC services flat-file account dumps, reading the
+// MU (account) and MN (nick group member) records documented in Atheme's
+// services.db(5) flatfile format.
+type Atheme struct{}
+
+// Parse implements Source.
+func (Atheme) Parse(r io.Reader) ([]Record, error) {
+	byEntity := make(map[string]*Record)
+	var order []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "MU":
+			if len(fields) < 6 {
+				continue
+			}
+
+			entity := fields[1]
+			registered, _ := strconv.ParseInt(fields[5], 10, 64)
+
+			byEntity[entity] = &Record{
+				Name:         fields[2],
+				Hash:         fields[3],
+				Email:        fields[4],
+				RegisteredAt: registered,
+			}
+			order = append(order, entity)
+
+		case "MN":
+			if len(fields) < 3 {
+				continue
+			}
+
+			entity, nick := fields[1], fields[2]
+			rec, ok := byEntity[entity]
+			if ok && !strings.EqualFold(rec.Name, nick) {
+				rec.AdditionalNicks = append(rec.AdditionalNicks, nick)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	out := make([]Record, 0, len(order))
+	for _, entity := range order {
+		rec := byEntity[entity]
+		_, rec.Recognized = identify([]byte(rec.Hash))
+		out = append(out, *rec)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,50 @@
+package migrate
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Hybrid parses the flat, colon-delimited account dump produced by the
+// services packages (e.g. hybserv/ircservices) commonly paired with
+// ircd-hybrid: "name:hash:email:registeredAt[:nick1,nick2,...]".
+type Hybrid struct{}
+
+// Parse implements Source.
+func (Hybrid) Parse(r io.Reader) ([]Record, error) {
+	var out []Record
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, ":")
+		if len(fields) < 4 {
+			continue
+		}
+
+		registered, _ := strconv.ParseInt(fields[3], 10, 64)
+		rec := Record{
+			Name:         fields[0],
+			Hash:         fields[1],
+			Email:        fields[2],
+			RegisteredAt: registered,
+		}
+		if len(fields) > 4 && fields[4] != "" {
+			rec.AdditionalNicks = strings.Split(fields[4], ",")
+		}
+
+		_, rec.Recognized = identify([]byte(rec.Hash))
+		out = append(out, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
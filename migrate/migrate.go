@@ -0,0 +1,68 @@
+// Package migrate imports foreign IRC-services account databases (Atheme
+// flat-file, Anope db_old, ircd-hybrid) into a normalized record stream,
+// round-tripping every stored hash through password.CrypterFound so an
+// operator can confirm this module parses it before trusting the import.
+package migrate // import "github.com/nathanaelle/password/migrate"
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/nathanaelle/password"
+)
+
+type (
+	// Record is the normalized account emitted for every source format.
+	Record struct {
+		Name            string   `json:"name"`
+		Hash            string   `json:"hash"`
+		Email           string   `json:"email"`
+		RegisteredAt    int64    `json:"registeredAt"`
+		AdditionalNicks []string `json:"additionalNicks,omitempty"`
+
+		// Recognized reports whether password.CrypterFound parsed Hash.
+		// false means the hash survived the import verbatim but can
+		// neither be verified nor upgraded by --rehash-to.
+		Recognized bool `json:"recognized"`
+	}
+
+	// Source parses one foreign database's dump format into Records.
+	Source interface {
+		Parse(r io.Reader) ([]Record, error)
+	}
+)
+
+// identify resolves hash through password.CrypterFound, falling back to a
+// password.UnrecognizedCrypter for prefixes this module doesn't recognize
+// so --rehash-to always fails for records stuck on an unknown scheme
+// instead of panicking on a nil Crypter. The second return value reports
+// which of those happened.
+func identify(hash []byte) (password.Crypter, bool) {
+	if c, ok := password.CrypterFound(hash); ok {
+		return c, true
+	}
+
+	return password.NewUnrecognizedCrypter(hash), false
+}
+
+// Rehash verifies pass against rec's stored hash and, on success, replaces
+// it with the encoding produced by driver, letting --rehash-to upgrade
+// hash strength as part of the import instead of a separate pass later.
+func Rehash(rec Record, pass []byte, driver password.Definition) (Record, error) {
+	c, recognized := identify([]byte(rec.Hash))
+	if !recognized {
+		return rec, fmt.Errorf("migrate: %s: hash format not supported by this tool, cannot verify or rehash", rec.Name)
+	}
+
+	if !c.Verify(pass) {
+		return rec, fmt.Errorf("migrate: %s: password does not match stored hash", rec.Name)
+	}
+
+	hash := driver.Crypt(pass, nil, nil)
+	if len(hash) == 0 {
+		return rec, fmt.Errorf("migrate: %s: rehash with %s failed", rec.Name, driver)
+	}
+
+	rec.Hash = string(hash)
+	return rec, nil
+}
@@ -0,0 +1,131 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAthemeParse(t *testing.T) {
+	in := strings.Join([]string{
+		"MU AAAAAAAAB alice $5$saltstring$5B8vYYiY.CVt1RlTTf8KbXBH3hsxY/GNooZaBBGWEc5 alice@example.com 1000000000",
+		"MN AAAAAAAAB alice 1000000000 0",
+		"MN AAAAAAAAB alice2 1000000000 0",
+	}, "\n")
+
+	recs, err := Atheme{}.Parse(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(recs) != 1 {
+		t.Fatalf("got %d records, want 1", len(recs))
+	}
+
+	rec := recs[0]
+	if rec.Name != "alice" {
+		t.Errorf("Name = %q, want %q", rec.Name, "alice")
+	}
+	if rec.Email != "alice@example.com" {
+		t.Errorf("Email = %q, want %q", rec.Email, "alice@example.com")
+	}
+	if rec.RegisteredAt != 1000000000 {
+		t.Errorf("RegisteredAt = %d, want %d", rec.RegisteredAt, 1000000000)
+	}
+	if len(rec.AdditionalNicks) != 1 || rec.AdditionalNicks[0] != "alice2" {
+		t.Errorf("AdditionalNicks = %v, want [alice2]", rec.AdditionalNicks)
+	}
+	if !rec.Recognized {
+		t.Error("Recognized = false, want true for a $5$ hash")
+	}
+}
+
+func TestAthemeParseUnrecognizedHash(t *testing.T) {
+	in := "MU AAAAAAAAB bob $6$rounds=5000$unknown$unknown bob@example.com 1000000000\n"
+
+	recs, err := Atheme{}.Parse(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(recs) != 1 {
+		t.Fatalf("got %d records, want 1", len(recs))
+	}
+	if recs[0].Recognized {
+		t.Error("Recognized = true, want false for an unsupported $6$ hash")
+	}
+}
+
+func TestAnopeParse(t *testing.T) {
+	in := strings.Join([]string{
+		"NICKNAME alice",
+		"PASS $5$saltstring$5B8vYYiY.CVt1RlTTf8KbXBH3hsxY/GNooZaBBGWEc5",
+		"EMAIL alice@example.com",
+		"TIME 1000000000",
+		"LINK alice2",
+		"NICKNAME bob",
+		"PASS $6$rounds=5000$unknown$unknown",
+		"EMAIL bob@example.com",
+	}, "\n")
+
+	recs, err := Anope{}.Parse(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(recs) != 2 {
+		t.Fatalf("got %d records, want 2", len(recs))
+	}
+
+	alice := recs[0]
+	if alice.Name != "alice" || alice.Email != "alice@example.com" {
+		t.Errorf("alice record = %+v", alice)
+	}
+	if alice.RegisteredAt != 1000000000 {
+		t.Errorf("alice.RegisteredAt = %d, want %d", alice.RegisteredAt, 1000000000)
+	}
+	if len(alice.AdditionalNicks) != 1 || alice.AdditionalNicks[0] != "alice2" {
+		t.Errorf("alice.AdditionalNicks = %v, want [alice2]", alice.AdditionalNicks)
+	}
+	if !alice.Recognized {
+		t.Error("alice.Recognized = false, want true for a $5$ hash")
+	}
+
+	bob := recs[1]
+	if bob.Name != "bob" || bob.Email != "bob@example.com" {
+		t.Errorf("bob record = %+v", bob)
+	}
+	if bob.Recognized {
+		t.Error("bob.Recognized = true, want false for an unsupported $6$ hash")
+	}
+}
+
+func TestHybridParse(t *testing.T) {
+	in := strings.Join([]string{
+		"# comment",
+		"",
+		"alice:$5$saltstring$5B8vYYiY.CVt1RlTTf8KbXBH3hsxY/GNooZaBBGWEc5:alice@example.com:1000000000:alice2,alice3",
+		"bob:$6$rounds=5000$unknown$unknown:bob@example.com:1000000000",
+		"malformed:only:three",
+	}, "\n")
+
+	recs, err := Hybrid{}.Parse(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(recs) != 2 {
+		t.Fatalf("got %d records, want 2", len(recs))
+	}
+
+	alice := recs[0]
+	if alice.Name != "alice" || alice.Email != "alice@example.com" {
+		t.Errorf("alice record = %+v", alice)
+	}
+	if len(alice.AdditionalNicks) != 2 || alice.AdditionalNicks[0] != "alice2" || alice.AdditionalNicks[1] != "alice3" {
+		t.Errorf("alice.AdditionalNicks = %v, want [alice2 alice3]", alice.AdditionalNicks)
+	}
+	if !alice.Recognized {
+		t.Error("alice.Recognized = false, want true for a $5$ hash")
+	}
+
+	bob := recs[1]
+	if bob.Recognized {
+		t.Error("bob.Recognized = true, want false for an unsupported $6$ hash")
+	}
+}
@@ -0,0 +1,37 @@
+package password // import "github.com/nathanaelle/password"
+
+// preferredOrder lists the registered drivers from strongest to weakest.
+// Passwd walks it to verify against any known hash format and IsPreferred
+// uses its head to flag hashes that should be rehashed on next login.
+var preferredOrder = []Definition{
+	SHA256,
+}
+
+// Passwd verifies pass against hash, dispatching to whichever registered
+// driver produced it, and returns the canonical encoding of that hash. It
+// takes and returns []byte throughout so callers reading secrets out of
+// mmap'd files can verify without an intermediate string copy.
+func Passwd(pass, hash []byte) ([]byte, error) {
+	c, ok := CrypterFound(hash)
+	if !ok {
+		return nil, ERR_NOPE
+	}
+
+	if !c.Verify(pass) {
+		return nil, ERR_NOPE
+	}
+
+	return c.MarshalText()
+}
+
+// IsPreferred reports whether hash was produced by the most preferred
+// registered driver, letting callers rehash on login as weaker schemes
+// (e.g. $1$ md5-crypt) are phased out in favor of stronger ones.
+func IsPreferred(hash []byte) bool {
+	c, ok := CrypterFound(hash)
+	if !ok || len(preferredOrder) == 0 {
+		return false
+	}
+
+	return c.Definition().String() == preferredOrder[0].String()
+}
@@ -0,0 +1,70 @@
+package password // import "github.com/nathanaelle/password"
+
+import (
+	"crypto/sha256"
+	"hash"
+	"sync"
+	"sync/atomic"
+)
+
+// hashPool pools idle hash.Hash instances and scratch digest buffers for
+// one algorithm, letting crypt() Reset+Write (and reuse a digest buffer)
+// instead of allocating a fresh hash.Hash and []byte on every one of up
+// to sha256MaxRounds rounds, plus the handful of one-off digests (sumB,
+// A, sumP, sumS) computed once per Crypt/Verify call.
+type hashPool struct {
+	hashes  sync.Pool
+	buffers sync.Pool
+}
+
+func newHashPool(newFn func() hash.Hash) *hashPool {
+	return &hashPool{
+		hashes:  sync.Pool{New: func() interface{} { return newFn() }},
+		buffers: sync.Pool{New: func() interface{} { b := make([]byte, 0, sha256.Size); return b }},
+	}
+}
+
+func (hp *hashPool) get() hash.Hash {
+	return hp.hashes.Get().(hash.Hash)
+}
+
+func (hp *hashPool) put(h hash.Hash) {
+	h.Reset()
+	hp.hashes.Put(h)
+}
+
+// buffer returns a zero-length scratch buffer for a caller to Sum/append
+// into; it must come back via putBuffer once the caller is done reading
+// it, never before.
+func (hp *hashPool) buffer() []byte {
+	return hp.buffers.Get().([]byte)[:0]
+}
+
+func (hp *hashPool) putBuffer(b []byte) {
+	hp.buffers.Put(b)
+}
+
+var sha256Pool atomic.Pointer[hashPool]
+
+func init() {
+	sha256Pool.Store(newHashPool(sha256.New))
+}
+
+func sha256HashPool() *hashPool {
+	return sha256Pool.Load()
+}
+
+// SetPool replaces the shared hash.Hash/buffer pool with a fresh one
+// pre-warmed with size idle hash.Hash instances, so a server doing bursty
+// login verification can front-load the allocations crypt() would
+// otherwise make on its first size concurrent calls. Like any sync.Pool,
+// entries beyond whatever the runtime decides to keep are still reclaimed
+// between garbage collections, so size is a warm-up hint, not a hard cap.
+func SetPool(size int) {
+	p := newHashPool(sha256.New)
+	for i := 0; i < size; i++ {
+		p.put(sha256.New())
+	}
+
+	sha256Pool.Store(p)
+}
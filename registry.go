@@ -11,8 +11,14 @@ type (
 		Options() map[string]interface{}
 		SetOptions(o map[string]interface{}) Definition
 		Default() Crypter
-		Crypt(pwd, salt []byte, options map[string]interface{}) string
-		CrypterFound(str string) (Crypter, bool)
+		Crypt(pwd, salt []byte, options map[string]interface{}) []byte
+		CrypterFound(hash []byte) (Crypter, bool)
+
+		// Prefix returns the leading "$id$" token (e.g. "$5$", "$apr1$")
+		// this driver's encoded hashes start with, or "" if its format
+		// isn't $id$-prefixed (DES crypt, {SHA}, {SSHA}, ...). register
+		// uses it to index the driver in the lookup trie.
+		Prefix() []byte
 	}
 
 	// Crypter is one parsed or freshly computed hash.
@@ -24,7 +30,14 @@ type (
 		Crypt(pwd []byte) Crypter
 		String() string
 		Verify(pwd []byte) bool
-		Set(str string) error
+		Set(hash []byte) error
+		MarshalText() ([]byte, error)
+		UnmarshalText(hash []byte) error
+	}
+
+	trieNode struct {
+		children map[byte]*trieNode
+		driver   Definition
 	}
 )
 
@@ -32,20 +45,72 @@ type (
 // expected format.
 var ERR_NOPE = errors.New("password: hash does not match this format")
 
-var drivers []Definition
+var (
+	dollarTrie = &trieNode{}
+	legacy     []Definition
+)
 
-// register records d in the driver registry so Identify/CrypterFound can
-// recognize hashes it produces.
+// register records d in the driver registry: drivers whose Prefix starts
+// with "$" are indexed in dollarTrie so Identify resolves them in
+// O(len(prefix)) without scanning every registered driver; everything
+// else (DES crypt, {SHA}, {SSHA}, {CRYPT} LDAP wrappers) falls into the
+// legacy list that Identify probes via CrypterFound as a last resort.
 func register(d Definition) Definition {
-	drivers = append(drivers, d)
+	if prefix := d.Prefix(); len(prefix) > 0 && prefix[0] == '$' {
+		dollarTrie.insert(prefix, d)
+	} else {
+		legacy = append(legacy, d)
+	}
+
 	return d
 }
 
-// Identify returns the Definition that owns str's encoding by probing
-// every registered driver's CrypterFound in registration order.
-func Identify(str string) (Definition, bool) {
-	for _, d := range drivers {
-		if _, ok := d.CrypterFound(str); ok {
+func (n *trieNode) insert(prefix []byte, d Definition) {
+	for _, b := range prefix {
+		child, ok := n.children[b]
+		if !ok {
+			child = &trieNode{}
+			if n.children == nil {
+				n.children = make(map[byte]*trieNode)
+			}
+			n.children[b] = child
+		}
+		n = child
+	}
+	n.driver = d
+}
+
+func (n *trieNode) lookup(hash []byte) Definition {
+	var found Definition
+
+	for _, b := range hash {
+		child, ok := n.children[b]
+		if !ok {
+			break
+		}
+		n = child
+		if n.driver != nil {
+			found = n.driver
+		}
+	}
+
+	return found
+}
+
+// Identify returns the Definition that owns hash's encoding, trying the
+// $id$ trie first and falling back to the legacy, non-$id$-prefixed
+// drivers, without allocating on the trie path.
+func Identify(hash []byte) (Definition, bool) {
+	if len(hash) > 0 && hash[0] == '$' {
+		if d := dollarTrie.lookup(hash); d != nil {
+			if _, ok := d.CrypterFound(hash); ok {
+				return d, true
+			}
+		}
+	}
+
+	for _, d := range legacy {
+		if _, ok := d.CrypterFound(hash); ok {
 			return d, true
 		}
 	}
@@ -53,13 +118,13 @@ func Identify(str string) (Definition, bool) {
 	return nil, false
 }
 
-// CrypterFound identifies str's driver via Identify and parses it into a
+// CrypterFound identifies hash's driver via Identify and parses it into a
 // Crypter, or reports false if no registered driver recognizes it.
-func CrypterFound(str string) (Crypter, bool) {
-	d, ok := Identify(str)
+func CrypterFound(hash []byte) (Crypter, bool) {
+	d, ok := Identify(hash)
 	if !ok {
 		return nil, false
 	}
 
-	return d.CrypterFound(str)
+	return d.CrypterFound(hash)
 }
@@ -0,0 +1,59 @@
+package password
+
+import "testing"
+
+func TestIdentify(t *testing.T) {
+	sha256hash := (&sha256pwd{rounds: sha256DefRounds}).Salt([]byte("saltstring")).Crypt([]byte("Hello world!")).(*sha256pwd).String()
+	apr1hash := (&apr1pwd{}).Salt([]byte("r31.CWaZ")).Crypt([]byte("myPassword")).(*apr1pwd).String()
+	bcrypthash := (&bcryptpwd{cost: 4}).Crypt([]byte("hunter2")).(*bcryptpwd).String()
+	shahash := (&lshapwd{salted: false}).Crypt([]byte("hunter2")).(*lshapwd).String()
+	sshahash := (&lshapwd{salted: true}).Salt(nil).Crypt([]byte("hunter2")).(*lshapwd).String()
+
+	tests := []struct {
+		name string
+		hash string
+		want Definition
+	}{
+		{"sha256", sha256hash, SHA256},
+		{"apr1", apr1hash, APR1},
+		{"bcrypt", bcrypthash, BCRYPT},
+		{"sha1", shahash, SHA1},
+		{"ssha1", sshahash, SSHA1},
+		{"unknown $6$", "$6$rounds=5000$unknown$unknown", nil},
+		{"unknown legacy", "plaintextcryptDES", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, ok := Identify([]byte(tt.hash))
+			if tt.want == nil {
+				if ok {
+					t.Fatalf("Identify(%q) = %v, true; want not found", tt.hash, d)
+				}
+				return
+			}
+
+			if !ok {
+				t.Fatalf("Identify(%q) = _, false; want %s", tt.hash, tt.want.String())
+			}
+			if d.String() != tt.want.String() {
+				t.Fatalf("Identify(%q) driver = %s; want %s", tt.hash, d.String(), tt.want.String())
+			}
+		})
+	}
+}
+
+func TestCrypterFoundVerifies(t *testing.T) {
+	hash := SHA256.Crypt([]byte("correct horse"), nil, nil)
+
+	c, ok := CrypterFound(hash)
+	if !ok {
+		t.Fatalf("CrypterFound(%q) = _, false", hash)
+	}
+	if !c.Verify([]byte("correct horse")) {
+		t.Fatal("Verify of the matching password failed")
+	}
+	if c.Verify([]byte("wrong")) {
+		t.Fatal("Verify of a non-matching password succeeded")
+	}
+}
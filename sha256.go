@@ -1,10 +1,9 @@
 package password // import "github.com/nathanaelle/password"
 
 import (
-	"crypto/sha256"
+	"bytes"
 	"crypto/subtle"
 	"fmt"
-	"strings"
 )
 
 type (
@@ -34,6 +33,10 @@ func (d sha256driver) String() string {
 	return "{SHA256-CRYPT}"
 }
 
+func (d sha256driver) Prefix() []byte {
+	return []byte(sha256Prefix)
+}
+
 func (d sha256driver) Options() map[string]interface{} {
 	return map[string]interface{}{
 		"rounds": d.rounds,
@@ -59,17 +62,18 @@ func (d sha256driver) Default() Crypter {
 	}
 }
 
-func (d sha256driver) Crypt(pwd, salt []byte, options map[string]interface{}) string {
-	return d.SetOptions(options).Default().Salt(salt).Crypt(pwd).String()
+func (d sha256driver) Crypt(pwd, salt []byte, options map[string]interface{}) []byte {
+	b, _ := d.SetOptions(options).Default().Salt(salt).Crypt(pwd).MarshalText()
+	return b
 }
 
-func (d sha256driver) CrypterFound(str string) (Crypter, bool) {
-	if len(str) < len(sha256Prefix) || str[0:len(sha256Prefix)] != sha256Prefix {
+func (d sha256driver) CrypterFound(hash []byte) (Crypter, bool) {
+	if len(hash) < len(sha256Prefix) || string(hash[0:len(sha256Prefix)]) != sha256Prefix {
 		return nil, false
 	}
 
 	p := new(sha256pwd)
-	if err := p.Set(str); err != nil {
+	if err := p.Set(hash); err != nil {
 		return nil, false
 	}
 
@@ -138,36 +142,36 @@ func (p *sha256pwd) Verify(pwd []byte) bool {
 	return (subtle.ConstantTimeCompare(he, p.hashed[:]) == 1)
 }
 
-func (p *sha256pwd) Set(str string) error {
+func (p *sha256pwd) Set(hash []byte) error {
 	if p == nil {
 		return ERR_NOPE
 	}
 
-	if len(str) < len(sha256Prefix) || str[0:len(sha256Prefix)] != sha256Prefix {
+	if len(hash) < len(sha256Prefix) || string(hash[0:len(sha256Prefix)]) != sha256Prefix {
 		return ERR_NOPE
 	}
 
-	if len(str) == len(sha256Prefix) {
+	if len(hash) == len(sha256Prefix) {
 		*p = sha256pwd{rounds: sha256DefRounds}
 		return nil
 	}
 
-	list := strings.SplitN(str[len(sha256Prefix):], "$", 3)
+	list := bytes.SplitN(hash[len(sha256Prefix):], []byte("$"), 3)
 
-	if list[len(list)-1] == "" {
+	if len(list[len(list)-1]) == 0 {
 		list = list[:len(list)-1]
 	}
 
 	opt := options(list[0])
 	if opt == nil {
-		np := (&sha256pwd{rounds: sha256DefRounds}).Salt([]byte(list[0]))
+		np := (&sha256pwd{rounds: sha256DefRounds}).Salt(list[0])
 		switch len(list) {
 		case 1:
 			*p = *(np.(*sha256pwd))
 			return nil
 
 		case 2:
-			*p = *(np.Hashed([]byte(list[1])).(*sha256pwd))
+			*p = *(np.Hashed(list[1]).(*sha256pwd))
 			return nil
 		}
 		return ERR_NOPE
@@ -185,11 +189,11 @@ func (p *sha256pwd) Set(str string) error {
 		return nil
 
 	case 2:
-		*p = *(np.Salt([]byte(list[1])).(*sha256pwd))
+		*p = *(np.Salt(list[1]).(*sha256pwd))
 		return nil
 
 	case 3:
-		*p = *(np.Salt([]byte(list[1])).Hashed([]byte(list[2])).(*sha256pwd))
+		*p = *(np.Salt(list[1]).Hashed(list[2]).(*sha256pwd))
 		return nil
 	}
 
@@ -200,18 +204,53 @@ func (p *sha256pwd) MarshalText() ([]byte, error) {
 	return []byte(p.String()), nil
 }
 
-func (p *sha256pwd) crypt(pwd []byte) [32]byte {
-	sumB := commonSum(sha256.New(), pwd, p.salt, pwd).Sum(nil)
-
-	A := commonSum(sha256.New(), pwd, p.salt, repeatBytes(sumB, len(pwd)))
-	sumA := commonSum(A, commonMixer(len(pwd), sumB, pwd)...).Sum(nil)
+// UnmarshalText implements encoding.TextUnmarshaler, parsing hash directly as
+// []byte so callers verifying secrets read from mmap'd files avoid the extra
+// string copy that Set's former string-based signature used to force.
+func (p *sha256pwd) UnmarshalText(hash []byte) error {
+	return p.Set(hash)
+}
 
-	sumP := repeatBytes(commonSum(sha256.New(), multiplyBytes(pwd, len(pwd))...).Sum(nil), len(pwd))
-	sumS := repeatBytes(commonSum(sha256.New(), multiplyBytes(p.salt, (16+int(sumA[0])))...).Sum(nil), len(p.salt))
+func (p *sha256pwd) crypt(pwd []byte) [32]byte {
+	pool := sha256HashPool()
+
+	hB := pool.get()
+	defer pool.put(hB)
+	bufB := pool.buffer()
+	defer pool.putBuffer(bufB)
+	sumB := commonSum(hB, pwd, p.salt, pwd).Sum(bufB)
+
+	hA := pool.get()
+	defer pool.put(hA)
+	bufA := pool.buffer()
+	defer pool.putBuffer(bufA)
+	commonSum(hA, pwd, p.salt, repeatBytes(sumB, len(pwd)))
+	sumA := commonSum(hA, commonMixer(len(pwd), sumB, pwd)...).Sum(bufA)
+
+	hP := pool.get()
+	defer pool.put(hP)
+	bufP := pool.buffer()
+	defer pool.putBuffer(bufP)
+	sumP := repeatBytes(commonSum(hP, multiplyBytes(pwd, len(pwd))...).Sum(bufP), len(pwd))
+
+	hS := pool.get()
+	defer pool.put(hS)
+	bufS := pool.buffer()
+	defer pool.putBuffer(bufS)
+	sumS := repeatBytes(commonSum(hS, multiplyBytes(p.salt, (16+int(sumA[0])))...).Sum(bufS), len(p.salt))
+
+	h := pool.get()
+	defer pool.put(h)
 
 	sumC := sumA
+	scratch := pool.buffer()
+	defer pool.putBuffer(scratch)
 	for i := 0; i < p.rounds; i++ {
-		sumC = commonSum(sha256.New(), commonDispatch(i, sumC, sumP, sumS)...).Sum(nil)
+		h.Reset()
+		for _, b := range commonDispatch(i, sumC, sumP, sumS) {
+			h.Write(b)
+		}
+		sumC = h.Sum(scratch[:0])
 	}
 
 	return [32]byte{
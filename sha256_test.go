@@ -0,0 +1,21 @@
+package password
+
+import "testing"
+
+func BenchmarkSHA256Crypt5000(b *testing.B) {
+	benchmarkSHA256Crypt(b, 5000)
+}
+
+func BenchmarkSHA256Crypt500000(b *testing.B) {
+	benchmarkSHA256Crypt(b, 500000)
+}
+
+func benchmarkSHA256Crypt(b *testing.B, rounds int) {
+	p := &sha256pwd{rounds: rounds, salt: []byte("saltsalt")}
+	pwd := []byte("correct horse battery staple")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p.crypt(pwd)
+	}
+}
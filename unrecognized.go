@@ -0,0 +1,36 @@
+package password // import "github.com/nathanaelle/password"
+
+// UnrecognizedCrypter wraps a hash no registered driver claimed (e.g. a
+// $6$ or plain DES-crypt line) so callers that keep one Crypter per
+// entry - htpasswd files, migrate's foreign-database importers - can
+// carry it through unverified rather than dropping or erroring on it.
+// Verify always fails and Crypt/Salt/Hashed are no-ops, since there is no
+// driver to compute or re-parse it with; String/MarshalText return it
+// byte-for-byte so a round trip doesn't lose or corrupt the line.
+type UnrecognizedCrypter struct {
+	hash []byte
+}
+
+// NewUnrecognizedCrypter wraps hash, copying it so the caller's slice can
+// be reused or mutated afterwards.
+func NewUnrecognizedCrypter(hash []byte) *UnrecognizedCrypter {
+	return &UnrecognizedCrypter{hash: append([]byte(nil), hash...)}
+}
+
+func (u *UnrecognizedCrypter) Salt(salt []byte) Crypter     { return u }
+func (u *UnrecognizedCrypter) Hashed(hashed []byte) Crypter { return u }
+func (u *UnrecognizedCrypter) Options() map[string]interface{} {
+	return map[string]interface{}{}
+}
+func (u *UnrecognizedCrypter) Definition() Definition   { return nil }
+func (u *UnrecognizedCrypter) Crypt(pwd []byte) Crypter { return u }
+func (u *UnrecognizedCrypter) String() string           { return string(u.hash) }
+func (u *UnrecognizedCrypter) Verify(pwd []byte) bool   { return false }
+func (u *UnrecognizedCrypter) Set(hash []byte) error {
+	u.hash = append([]byte(nil), hash...)
+	return nil
+}
+func (u *UnrecognizedCrypter) MarshalText() ([]byte, error) { return u.hash, nil }
+func (u *UnrecognizedCrypter) UnmarshalText(hash []byte) error {
+	return u.Set(hash)
+}